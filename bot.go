@@ -5,13 +5,15 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/magunetto/moviemagnetbot/movie"
+	"github.com/magunetto/moviemagnetbot/stream"
+	"github.com/magunetto/moviemagnetbot/torrent/quality"
 
 	"gopkg.in/tucnak/telebot.v2"
 )
@@ -20,16 +22,18 @@ const (
 	userFeedTitle = "Movie Magnet Bot feed"
 	userFeedURL   = "https://moviemagnetbot.herokuapp.com/tasks/%s.xml"
 
-	replyHelp       = "What movies do you like? Try me with the title, or just send the IMDb / Douban links"
-	replyRarbgErr   = "We encountered an error while finding magnet links, please try again"
-	replyTMDbErr    = "We encountered an error while finding movies, please try again"
-	replyNoIMDbIDs  = "We encountered an error while finding IMDb IDs for you: "
-	replyNoTorrents = "We have no magnet links for this movie now, please come back later"
-	replyNoPubStamp = "We could not find this magnet link, please check your input"
-	replyNoTMDb     = "We could not find this movie on TMDb, please check your input"
-	replyNoTorrent  = "We encountered an error while finding this magnet link"
-	replyFeedTips   = "Auto-download every link you requested by subscribing " + userFeedURL
-	replyTaskAdded  = "Task added to your feed, it will start soon"
+	replyHelp         = "What movies do you like? Try me with the title, or just send the IMDb / Douban links"
+	replyRarbgErr     = "We encountered an error while finding magnet links, please try again"
+	replyTMDbErr      = "We encountered an error while finding movies, please try again"
+	replyNoIMDbIDs    = "We encountered an error while finding IMDb IDs for you: "
+	replyNoTorrents   = "We have no magnet links for this movie now, please come back later"
+	replyNoPubStamp   = "We could not find this magnet link, please check your input"
+	replyNoTMDb       = "We could not find this movie on TMDb, please check your input"
+	replyNoTorrent    = "We encountered an error while finding this magnet link"
+	replyFeedTips     = "Auto-download every link you requested by subscribing " + userFeedURL
+	replyTaskAdded    = "Task added to your feed, it will start soon"
+	replyStreamErr    = "We added the magnet but could not start streaming it, please download it instead"
+	replyIsCamRelease = "This is a cam/telesync release, which is hidden by default. Send \"/dl<stamp> cam\" if you really want it"
 
 	cmdPrefixDown = "/dl"
 	cmdPrefixTMDb = "/tmdb"
@@ -37,11 +41,32 @@ const (
 	itemsPerMovieSearch = 5
 	itemsPerFeed        = 20
 	feedCheckThreshold  = time.Duration(24 * time.Hour)
+
+	streamDataDir    = "data/torrents"
+	streamStateFile  = "data/streams.json"
+	streamListenAddr = ":8081"
+	streamBaseURL    = "https://moviemagnetbot.herokuapp.com"
 )
 
+var streamSrv *stream.Server
+
 // RunBot init bot, register handlers, and start the bot
 func RunBot() {
 
+	// init magnet search sources
+	initTorrentSources()
+
+	// init streaming subsystem
+	streamMgr, err := stream.NewManager(streamDataDir, streamStateFile)
+	if err != nil {
+		log.Fatalf("error while creating stream manager: %s", err)
+	}
+	streamSrv = stream.NewServer(streamMgr)
+	go func() {
+		http.Handle("/stream/", streamSrv)
+		log.Fatal(http.ListenAndServe(streamListenAddr, nil))
+	}()
+
 	// init bot
 	b, err := telebot.NewBot(telebot.Settings{
 		Token:  os.Getenv("MOVIE_MAGNET_BOT_TOKEN"),
@@ -58,9 +83,23 @@ func RunBot() {
 	b.Handle("/help", func(m *telebot.Message) {
 		b.Send(m.Sender, replyHelp)
 	})
+	b.Handle(cmdSetClient, func(m *telebot.Message) {
+		startSetClient(b, m)
+	})
+	b.Handle(cmdTasks, func(m *telebot.Message) {
+		tasksHandler(b, m)
+	})
+	b.Handle(cmdPause, func(m *telebot.Message) {
+		pauseHandler(b, m)
+	})
 	b.Handle(telebot.OnText, func(m *telebot.Message) {
 		log.Printf("@%s: %s", m.Sender.Username, m.Text)
 
+		// continue a /setclient conversation, if one is in progress
+		if continueSetClient(b, m) {
+			return
+		}
+
 		// download requst
 		if strings.HasPrefix(m.Text, cmdPrefixDown) {
 			downloadHandler(b, m)
@@ -82,31 +121,58 @@ func RunBot() {
 
 func downloadHandler(b *telebot.Bot, m *telebot.Message) {
 
-	// get `PubStamp` from command, e.g. /dl1514983115
-	pubStampString := m.Text[len(cmdPrefixDown):len(m.Text)]
-	pubStamp, err := strconv.Atoi(pubStampString)
-	if err != nil {
-		log.Printf("error while parsing timestamp: %s", err)
+	// get the `PubStamp` or info hash, and an optional quality filter, from
+	// the command, e.g. /dl1514983115 1080p bluray no-cam or
+	// /dl2b66980... (a 40-hex info hash)
+	args := strings.Fields(m.Text[len(cmdPrefixDown):])
+	if len(args) == 0 {
 		b.Send(m.Sender, replyNoPubStamp)
 		return
 	}
 
-	// get torrent by `PubStamp`
-	t := &Torrent{PubStamp: int64(pubStamp)}
-	t, err = t.getByPubStamp()
+	u := &User{
+		TelegramID:   m.Sender.ID,
+		TelegramName: m.Sender.Username,
+	}
+
+	// A suffix here is a one-off exception for this download only (e.g.
+	// "/dl<stamp> cam" to pull a cam release despite a saved filter that
+	// hides them) — it must not overwrite the user's saved default.
+	filter := u.Filter()
+	if len(args) > 1 {
+		filter = quality.ParseFilter(filter, strings.Join(args[1:], " "))
+	}
+
+	t, err := lookupTorrent(args[0])
 	if err != nil {
+		if err == errBadTorrentRef {
+			b.Send(m.Sender, replyNoPubStamp)
+			return
+		}
 		log.Printf("error while getting torrent: %s", err)
 		b.Send(m.Sender, replyNoTorrent)
 		return
 	}
+
+	if attrs := quality.Parse(t.Title); attrs.CamOrTS && !filter.AllowCam {
+		b.Send(m.Sender, replyIsCamRelease)
+		return
+	}
+
 	magnet := &t.Magnet
 	b.Send(m.Sender, "`"+*magnet+"`", &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
 
+	// Anything that waits on the torrent swarm (metadata/metainfo lookups,
+	// up to ~40s combined) runs in the background, so the client push and
+	// the feed replies below go out without waiting for it.
+	go func() {
+		sendStreamLinks(b, m, *magnet)
+		sendFileTree(b, m, *magnet)
+	}()
+
+	pushToClient(b, m, *magnet)
+
 	// save the torrent for user
-	u := &User{
-		TelegramID:   m.Sender.ID,
-		TelegramName: m.Sender.Username,
-	}
 	err = u.appendTorrent(t)
 	if err != nil {
 		log.Printf("error while adding torrent for user: %s", err)
@@ -120,11 +186,33 @@ func downloadHandler(b *telebot.Bot, m *telebot.Message) {
 	b.Send(m.Sender, fmt.Sprintf(replyFeedTips, u.FeedID))
 }
 
+// sendStreamLinks starts a stream for the given magnet and sends the user a
+// direct playback URL together with .m3u/.strm snippets for VLC/mpv/Kodi.
+func sendStreamLinks(b *telebot.Bot, m *telebot.Message, magnet string) {
+	s, err := streamSrv.Manager().StartStream(magnet)
+	if err != nil {
+		log.Printf("error while starting stream: %s", err)
+		b.Send(m.Sender, replyStreamErr)
+		return
+	}
+
+	url := streamSrv.URL(streamBaseURL, s.InfoHash)
+	name := s.InfoHash
+	for _, st := range streamSrv.Manager().Status() {
+		if st.InfoHash == s.InfoHash {
+			name = st.Name
+		}
+	}
+	b.Send(m.Sender, url)
+	b.Send(m.Sender, "```\n"+stream.M3U(name, url)+"```", &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
+	b.Send(m.Sender, "```\n"+stream.Strm(url)+"```", &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
+}
+
 func tmdbHandler(b *telebot.Bot, m *telebot.Message) {
 	tmdbID := m.Text[len(cmdPrefixTMDb):len(m.Text)]
 	buffer := new(bytes.Buffer)
 	fmt.Fprintf(buffer, "§ %s\n", m.Text)
-	searchTorrents(buffer, "tmdb", tmdbID)
+	searchTorrents(buffer, m.Sender.ID, "tmdb", tmdbID)
 	b.Send(m.Sender, buffer.String(), &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
 }
 
@@ -199,13 +287,34 @@ func renderMovies(w io.Writer, movies []movie.Movie) {
 		command := fmt.Sprintf("%s%d", cmdPrefixTMDb, m.TMDbID)
 		fmt.Fprintf(w, "%s (%s)\n", m.Title, m.Date[0:4])
 		fmt.Fprintf(w, "▸ %s [¶](%s)\n", command, m.TMDbURL)
+		if m.Genre != "" || m.Runtime != "" {
+			fmt.Fprintf(w, "%s · %s\n", m.Genre, m.Runtime)
+		}
+		if m.Rated != "" {
+			fmt.Fprintf(w, "Rated %s\n", m.Rated)
+		}
+		if m.Plot != "" {
+			fmt.Fprintf(w, "%s\n", m.Plot)
+		}
+		if m.Director != "" {
+			fmt.Fprintf(w, "Director: %s\n", m.Director)
+		}
+		if m.Actors != "" {
+			fmt.Fprintf(w, "Cast: %s\n", m.Actors)
+		}
+		if m.Awards != "" {
+			fmt.Fprintf(w, "%s\n", m.Awards)
+		}
+		if m.Poster != "" {
+			fmt.Fprintf(w, "[Poster](%s)\n", m.Poster)
+		}
 	}
 }
 
 func torrentSearchHandler(b *telebot.Bot, m *telebot.Message, id string) {
 	result := new(bytes.Buffer)
 	fmt.Fprintf(result, "§ /%s\n", id)
-	isSingleResult := searchTorrents(result, "imdb", id)
+	isSingleResult := searchTorrents(result, m.Sender.ID, "imdb", id)
 	b.Send(m.Sender, result.String(),
 		&telebot.SendOptions{ParseMode: telebot.ModeMarkdown, DisableWebPagePreview: !isSingleResult})
 }
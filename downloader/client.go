@@ -0,0 +1,54 @@
+// Package downloader pushes accepted magnets straight into a user's own
+// torrent client instead of (or in addition to) the bot's RSS feed.
+package downloader
+
+// Task is a single download tracked by a Client.
+type Task struct {
+	Hash     string
+	Name     string
+	Progress float64 // 0..1
+	State    string
+}
+
+// Client is implemented by every supported torrent box.
+type Client interface {
+	// AddMagnet adds a magnet to the client under the given category and
+	// save path, returning once the client has accepted it.
+	AddMagnet(magnet, category, savePath string) error
+	// Status lists the client's current tasks.
+	Status() ([]Task, error)
+	// Pause pauses a task by info hash.
+	Pause(hash string) error
+	// Resume resumes a previously paused task.
+	Resume(hash string) error
+}
+
+// Config holds the per-user credentials needed to reach a Client, stored
+// alongside the user's other preferences.
+type Config struct {
+	Kind     string // "qbittorrent", "transmission", "deluge"
+	Host     string
+	User     string
+	Pass     string
+	Category string
+}
+
+// New builds the Client for a user's configured torrent box.
+func New(cfg Config) (Client, error) {
+	switch cfg.Kind {
+	case "qbittorrent":
+		return newQBittorrentClient(cfg), nil
+	case "transmission":
+		return newTransmissionClient(cfg), nil
+	case "deluge":
+		return newDelugeClient(cfg), nil
+	default:
+		return nil, errUnknownKind(cfg.Kind)
+	}
+}
+
+type errUnknownKind string
+
+func (k errUnknownKind) Error() string {
+	return "downloader: unknown client kind " + string(k)
+}
@@ -0,0 +1,116 @@
+package downloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// delugeClient implements Client on top of Deluge's JSON-RPC WebUI API.
+type delugeClient struct {
+	cfg       Config
+	http      *http.Client
+	loggedIn  bool
+	requestID int
+}
+
+func newDelugeClient(cfg Config) *delugeClient {
+	return &delugeClient{cfg: cfg, http: &http.Client{}}
+}
+
+type delugeRequest struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+	ID     int           `json:"id"`
+}
+
+type delugeResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *delugeClient) call(method string, params []interface{}, out interface{}) error {
+	if err := c.login(); err != nil {
+		return err
+	}
+
+	c.requestID++
+	body, err := json.Marshal(delugeRequest{Method: method, Params: params, ID: c.requestID})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Post(c.cfg.Host+"/json", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var dr delugeResponse
+	if err := decodeJSON(resp.Body, &dr); err != nil {
+		return err
+	}
+	if dr.Error != nil {
+		return fmt.Errorf("deluge: %s", dr.Error.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(dr.Result, out)
+}
+
+func (c *delugeClient) login() error {
+	if c.loggedIn {
+		return nil
+	}
+	c.loggedIn = true // avoid recursing into call() from within login
+	var ok bool
+	if err := c.call("auth.login", []interface{}{c.cfg.Pass}, &ok); err != nil {
+		c.loggedIn = false
+		return err
+	}
+	if !ok {
+		c.loggedIn = false
+		return fmt.Errorf("deluge: login rejected")
+	}
+	return nil
+}
+
+// AddMagnet does not send category to Deluge; only savePath is passed on,
+// as download_location.
+func (c *delugeClient) AddMagnet(magnet, category, savePath string) error {
+	options := map[string]interface{}{}
+	if savePath != "" {
+		options["download_location"] = savePath
+	}
+	return c.call("core.add_torrent_magnet", []interface{}{magnet, options}, nil)
+}
+
+func (c *delugeClient) Status() ([]Task, error) {
+	var raw map[string]struct {
+		Name     string  `json:"name"`
+		Progress float64 `json:"progress"`
+		State    string  `json:"state"`
+	}
+	fields := []string{"name", "progress", "state"}
+	if err := c.call("core.get_torrents_status", []interface{}{map[string]interface{}{}, fields}, &raw); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]Task, 0, len(raw))
+	for hash, t := range raw {
+		tasks = append(tasks, Task{Hash: hash, Name: t.Name, Progress: t.Progress / 100, State: t.State})
+	}
+	return tasks, nil
+}
+
+func (c *delugeClient) Pause(hash string) error {
+	return c.call("core.pause_torrent", []interface{}{[]string{hash}}, nil)
+}
+
+func (c *delugeClient) Resume(hash string) error {
+	return c.call("core.resume_torrent", []interface{}{[]string{hash}}, nil)
+}
@@ -0,0 +1,112 @@
+package downloader
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+)
+
+// qbittorrentClient implements Client on top of qBittorrent's Web API.
+type qbittorrentClient struct {
+	cfg      Config
+	http     *http.Client
+	loggedIn bool
+}
+
+func newQBittorrentClient(cfg Config) *qbittorrentClient {
+	jar, _ := cookiejar.New(nil)
+	return &qbittorrentClient{cfg: cfg, http: &http.Client{Jar: jar}}
+}
+
+func (c *qbittorrentClient) login() error {
+	if c.loggedIn {
+		return nil
+	}
+	resp, err := c.http.PostForm(c.cfg.Host+"/api/v2/auth/login", url.Values{
+		"username": {c.cfg.User},
+		"password": {c.cfg.Pass},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("qbittorrent: login failed with status %d", resp.StatusCode)
+	}
+	c.loggedIn = true
+	return nil
+}
+
+func (c *qbittorrentClient) AddMagnet(magnet, category, savePath string) error {
+	if err := c.login(); err != nil {
+		return err
+	}
+	form := url.Values{"urls": {magnet}}
+	if category != "" {
+		form.Set("category", category)
+	}
+	if savePath != "" {
+		form.Set("savepath", savePath)
+	}
+	resp, err := c.http.PostForm(c.cfg.Host+"/api/v2/torrents/add", form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("qbittorrent: add failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *qbittorrentClient) Status() ([]Task, error) {
+	if err := c.login(); err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Get(c.cfg.Host + "/api/v2/torrents/info")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw []struct {
+		Hash     string  `json:"hash"`
+		Name     string  `json:"name"`
+		Progress float64 `json:"progress"`
+		State    string  `json:"state"`
+	}
+	if err := decodeJSON(resp.Body, &raw); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]Task, 0, len(raw))
+	for _, t := range raw {
+		tasks = append(tasks, Task{Hash: t.Hash, Name: t.Name, Progress: t.Progress, State: t.State})
+	}
+	return tasks, nil
+}
+
+func (c *qbittorrentClient) Pause(hash string) error {
+	return c.postHashes("/api/v2/torrents/pause", hash)
+}
+
+func (c *qbittorrentClient) Resume(hash string) error {
+	return c.postHashes("/api/v2/torrents/resume", hash)
+}
+
+func (c *qbittorrentClient) postHashes(path, hash string) error {
+	if err := c.login(); err != nil {
+		return err
+	}
+	resp, err := c.http.PostForm(c.cfg.Host+path, url.Values{"hashes": {strings.ToLower(hash)}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("qbittorrent: request to %s failed with status %d", path, resp.StatusCode)
+	}
+	return nil
+}
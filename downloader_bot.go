@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/magunetto/moviemagnetbot/downloader"
+
+	"gopkg.in/tucnak/telebot.v2"
+)
+
+const (
+	cmdSetClient = "/setclient"
+	cmdTasks     = "/tasks"
+	cmdPause     = "/pause"
+
+	replySetClientStart  = "Which client do you use? Reply with qbittorrent, transmission or deluge"
+	replySetClientKind   = "Unknown client, please reply with qbittorrent, transmission or deluge"
+	replySetClientHost   = "What's its Web UI URL, e.g. http://localhost:8080?"
+	replySetClientUser   = "Username? (send \"-\" if none)"
+	replySetClientPass   = "Password? (send \"-\" if none)"
+	replySetClientDone   = "Saved! New magnets will be pushed to your %s automatically"
+	replyNoClient        = "You haven't configured a torrent client yet, send /setclient to set one up"
+	replyNoTasks         = "Your client has no tasks right now"
+	replyPauseUsage      = "Usage: /pause <hash>"
+	replyPauseOK         = "Paused"
+	replyClientTaskError = "We encountered an error while talking to your torrent client: "
+)
+
+// setClientStep walks a user through /setclient one reply at a time, since
+// telebot has no built-in conversation state.
+type setClientStep int
+
+const (
+	stepKind setClientStep = iota
+	stepHost
+	stepUser
+	stepPass
+)
+
+var setClientSessions = struct {
+	sync.Mutex
+	byTelegramID map[int]*setClientSession
+}{byTelegramID: map[int]*setClientSession{}}
+
+type setClientSession struct {
+	step setClientStep
+	cfg  downloader.Config
+}
+
+func startSetClient(b *telebot.Bot, m *telebot.Message) {
+	setClientSessions.Lock()
+	setClientSessions.byTelegramID[m.Sender.ID] = &setClientSession{step: stepKind}
+	setClientSessions.Unlock()
+	b.Send(m.Sender, replySetClientStart)
+}
+
+// continueSetClient advances an in-progress /setclient conversation, if the
+// sender has one. Returns true if it handled the message.
+func continueSetClient(b *telebot.Bot, m *telebot.Message) bool {
+	setClientSessions.Lock()
+	s, ok := setClientSessions.byTelegramID[m.Sender.ID]
+	setClientSessions.Unlock()
+	if !ok {
+		return false
+	}
+
+	text := strings.TrimSpace(m.Text)
+
+	switch s.step {
+	case stepKind:
+		kind := strings.ToLower(text)
+		if kind != "qbittorrent" && kind != "transmission" && kind != "deluge" {
+			b.Send(m.Sender, replySetClientKind)
+			return true
+		}
+		s.cfg.Kind = kind
+		s.step = stepHost
+		b.Send(m.Sender, replySetClientHost)
+
+	case stepHost:
+		s.cfg.Host = strings.TrimRight(text, "/")
+		s.step = stepUser
+		b.Send(m.Sender, replySetClientUser)
+
+	case stepUser:
+		if text != "-" {
+			s.cfg.User = text
+		}
+		s.step = stepPass
+		b.Send(m.Sender, replySetClientPass)
+
+	case stepPass:
+		if text != "-" {
+			s.cfg.Pass = text
+		}
+		s.cfg.Category = "moviemagnetbot"
+
+		u := &User{TelegramID: m.Sender.ID, TelegramName: m.Sender.Username}
+		u.SetClientConfig(s.cfg)
+		setClientSessions.Lock()
+		delete(setClientSessions.byTelegramID, m.Sender.ID)
+		setClientSessions.Unlock()
+
+		b.Send(m.Sender, fmt.Sprintf(replySetClientDone, s.cfg.Kind))
+	}
+
+	return true
+}
+
+// pushToClient pushes an accepted magnet to the user's configured torrent
+// client, if any, in addition to the RSS feed.
+func pushToClient(b *telebot.Bot, m *telebot.Message, magnet string) {
+	u := &User{TelegramID: m.Sender.ID, TelegramName: m.Sender.Username}
+	cfg, ok := u.ClientConfig()
+	if !ok {
+		return
+	}
+
+	client, err := downloader.New(cfg)
+	if err != nil {
+		log.Printf("error while creating downloader client: %s", err)
+		return
+	}
+	if err := client.AddMagnet(magnet, cfg.Category, ""); err != nil {
+		log.Printf("error while pushing magnet to client: %s", err)
+		b.Send(m.Sender, replyClientTaskError+err.Error())
+		return
+	}
+}
+
+func tasksHandler(b *telebot.Bot, m *telebot.Message) {
+	u := &User{TelegramID: m.Sender.ID, TelegramName: m.Sender.Username}
+	cfg, ok := u.ClientConfig()
+	if !ok {
+		b.Send(m.Sender, replyNoClient)
+		return
+	}
+
+	client, err := downloader.New(cfg)
+	if err != nil {
+		b.Send(m.Sender, replyClientTaskError+err.Error())
+		return
+	}
+
+	tasks, err := client.Status()
+	if err != nil {
+		b.Send(m.Sender, replyClientTaskError+err.Error())
+		return
+	}
+	if len(tasks) == 0 {
+		b.Send(m.Sender, replyNoTasks)
+		return
+	}
+
+	var sb strings.Builder
+	for _, t := range tasks {
+		fmt.Fprintf(&sb, "▸ %s (%s) %.0f%% `%s`\n", t.Name, t.State, t.Progress*100, t.Hash)
+	}
+	b.Send(m.Sender, sb.String(), &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
+}
+
+func pauseHandler(b *telebot.Bot, m *telebot.Message) {
+	u := &User{TelegramID: m.Sender.ID, TelegramName: m.Sender.Username}
+	cfg, ok := u.ClientConfig()
+	if !ok {
+		b.Send(m.Sender, replyNoClient)
+		return
+	}
+
+	hash := strings.TrimSpace(m.Text[len(cmdPause):])
+	if hash == "" {
+		b.Send(m.Sender, replyPauseUsage)
+		return
+	}
+
+	client, err := downloader.New(cfg)
+	if err != nil {
+		b.Send(m.Sender, replyClientTaskError+err.Error())
+		return
+	}
+	if err := client.Pause(hash); err != nil {
+		b.Send(m.Sender, replyClientTaskError+err.Error())
+		return
+	}
+	b.Send(m.Sender, replyPauseOK)
+}
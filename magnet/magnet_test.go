@@ -0,0 +1,57 @@
+package magnet
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	hash := "2b66980693c7aa0580d4b7280a6f6f06047dcb5a"
+	uri := "magnet:?xt=urn:btih:" + hash + "&dn=Some.Movie.2024&tr=udp://tracker.example:80&xl=123456"
+
+	m, err := Parse(uri)
+	if err != nil {
+		t.Fatalf("Parse returned error: %s", err)
+	}
+	if m.InfoHash != hash {
+		t.Errorf("InfoHash = %q, want %q", m.InfoHash, hash)
+	}
+	if m.DisplayName != "Some.Movie.2024" {
+		t.Errorf("DisplayName = %q, want %q", m.DisplayName, "Some.Movie.2024")
+	}
+	if len(m.Trackers) != 1 || m.Trackers[0] != "udp://tracker.example:80" {
+		t.Errorf("Trackers = %v, want [udp://tracker.example:80]", m.Trackers)
+	}
+	if m.Length != 123456 {
+		t.Errorf("Length = %d, want 123456", m.Length)
+	}
+}
+
+func TestParseUppercaseHash(t *testing.T) {
+	hash := "2B66980693C7AA0580D4B7280A6F6F06047DCB5A"
+	m, err := Parse("magnet:?xt=urn:btih:" + hash)
+	if err != nil {
+		t.Fatalf("Parse returned error: %s", err)
+	}
+	if m.InfoHash != "2b66980693c7aa0580d4b7280a6f6f06047dcb5a" {
+		t.Errorf("InfoHash = %q, want lowercased", m.InfoHash)
+	}
+}
+
+func TestParseRejectsBase32Hash(t *testing.T) {
+	_, err := Parse("magnet:?xt=urn:btih:ZBM2QCFQPGQVAVGUPOPAU235MBCH3Y3F")
+	if err == nil {
+		t.Fatal("Parse should reject a base32 info hash")
+	}
+}
+
+func TestParseRejectsNonMagnetURI(t *testing.T) {
+	_, err := Parse("https://example.com/not-a-magnet")
+	if err != errNotAMagnetURI {
+		t.Errorf("err = %v, want %v", err, errNotAMagnetURI)
+	}
+}
+
+func TestParseRejectsMissingInfoHash(t *testing.T) {
+	_, err := Parse("magnet:?dn=Some.Movie.2024")
+	if err == nil {
+		t.Fatal("Parse should reject a magnet URI with no btih info hash")
+	}
+}
@@ -0,0 +1,69 @@
+package magnet
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+// File is a single file inside a resolved torrent.
+type File struct {
+	Index  int
+	Path   string
+	Length int64
+}
+
+// Info is a Magnet enriched with its file list and total size, fetched from
+// the swarm (HTTP trackers/DHT) rather than decoded from the magnet URI
+// alone.
+type Info struct {
+	Magnet
+	Files []File
+}
+
+// Resolve fetches the torrent's metainfo over HTTP/DHT and returns the
+// magnet enriched with its file list and total size. timeout bounds how
+// long to wait for at least one peer to hand over the metadata. cl is an
+// already-running client (e.g. stream.Manager's) so the lookup reuses its
+// existing peer connections instead of starting a second swarm from
+// scratch.
+func Resolve(cl *torrent.Client, m *Magnet, timeout time.Duration) (*Info, error) {
+	t, _ := cl.AddTorrentInfoHash(metainfo.NewHashFromHex(m.InfoHash))
+	if len(m.Trackers) > 0 {
+		t.AddTrackers([][]string{m.Trackers})
+	}
+
+	select {
+	case <-t.GotInfo():
+	case <-time.After(timeout):
+		return nil, errResolveTimeout
+	}
+
+	info := &Info{Magnet: *m}
+	for i, f := range t.Files() {
+		info.Files = append(info.Files, File{Index: i, Path: f.Path(), Length: f.Length()})
+	}
+	info.Length = t.Length()
+
+	return info, nil
+}
+
+var errResolveTimeout = resolveTimeoutError{}
+
+type resolveTimeoutError struct{}
+
+func (resolveTimeoutError) Error() string {
+	return "magnet: timed out waiting for metainfo"
+}
+
+// SelectorMagnet builds a magnet URI limited to a single file, for
+// downloaders that support selective download via a "so=" (select-only)
+// query parameter.
+func SelectorMagnet(info *Info, fileIndex int) string {
+	return "magnet:?xt=urn:btih:" + info.InfoHash +
+		"&dn=" + url.QueryEscape(info.DisplayName) +
+		"&so=" + strconv.Itoa(fileIndex)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/magunetto/moviemagnetbot/magnet"
+
+	"gopkg.in/tucnak/telebot.v2"
+)
+
+const metainfoResolveTimeout = 10 * time.Second
+
+var reInfoHash = regexp.MustCompile(`^(?i)[0-9a-f]{40}$`)
+
+var errBadTorrentRef = errors.New("not a PubStamp or info hash")
+
+// lookupTorrent resolves a /dl argument that is either a legacy PubStamp
+// or a canonical 40-hex info hash.
+func lookupTorrent(ref string) (*Torrent, error) {
+	if reInfoHash.MatchString(ref) {
+		return (&Torrent{}).getByInfoHash(strings.ToLower(ref))
+	}
+
+	pubStamp, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil, errBadTorrentRef
+	}
+	return (&Torrent{PubStamp: int64(pubStamp)}).getByPubStamp()
+}
+
+// sendFileTree resolves the torrent's metainfo and, if it contains more
+// than one file, replies with a tappable list letting the user request a
+// specific file's magnet (for downloaders that support "so=" selective
+// download).
+func sendFileTree(b *telebot.Bot, m *telebot.Message, magnetURI string) {
+	parsed, err := magnet.Parse(magnetURI)
+	if err != nil {
+		return
+	}
+
+	info, err := magnet.Resolve(streamSrv.Manager().Client(), parsed, metainfoResolveTimeout)
+	if err != nil {
+		return
+	}
+	if len(info.Files) < 2 {
+		return
+	}
+
+	var sb strings.Builder
+	fmt.Fprintln(&sb, "This torrent has multiple files:")
+	for _, f := range info.Files {
+		fmt.Fprintf(&sb, "▸ %s\n`%s`\n", f.Path, magnet.SelectorMagnet(info, f.Index))
+	}
+	b.Send(m.Sender, sb.String(), &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
+}
@@ -0,0 +1,83 @@
+package movie
+
+import "sync"
+
+// lookupCache caches fully-enriched movies by TMDbID, the identifier every
+// search result already has (unlike the IMDb ID, which usually isn't known
+// until enrichment resolves it), so repeated keyword searches that turn up
+// the same title don't ask every other provider again. Only enrichment goes
+// through it: the primary provider's keyword search itself is not cached.
+var lookupCache = struct {
+	sync.RWMutex
+	byTMDbID map[int]Movie
+}{byTMDbID: map[int]Movie{}}
+
+func cacheGet(tmdbID int) (Movie, bool) {
+	lookupCache.RLock()
+	defer lookupCache.RUnlock()
+	m, ok := lookupCache.byTMDbID[tmdbID]
+	return m, ok
+}
+
+func cachePut(m Movie) {
+	if m.TMDbID == 0 {
+		return
+	}
+	lookupCache.Lock()
+	defer lookupCache.Unlock()
+	lookupCache.byTMDbID[m.TMDbID] = m
+}
+
+// enrichAll fills in whatever fields the primary provider left blank on
+// each movie, by asking every other provider for the same title. source is
+// the name of the provider that produced movies, so it isn't asked again.
+func enrichAll(movies []Movie, source string) {
+	for i := range movies {
+		enrich(&movies[i], source)
+	}
+}
+
+func enrich(m *Movie, source string) {
+	m.source = source
+
+	if cached, ok := cacheGet(m.TMDbID); ok {
+		m.mergeBlankFrom(cached)
+		return
+	}
+
+	// SearchMulti results don't carry an IMDb ID, only GetByTMDbID's
+	// detail lookup does. Resolve it from the source provider itself
+	// (normally skipped below, since it produced m and has nothing else
+	// left to add) so the by-IMDbID fallback pass has something to work
+	// with.
+	if m.imdbID == "" {
+		for _, p := range providers {
+			if p.Name() != source {
+				continue
+			}
+			if other, err := p.GetByTMDbID(m.TMDbID); err == nil && other != nil {
+				m.imdbID = other.imdbID
+			}
+			break
+		}
+	}
+
+	for _, p := range providers {
+		if p.Name() == source {
+			continue
+		}
+		if other, err := p.GetByTMDbID(m.TMDbID); err == nil && other != nil {
+			m.mergeBlankFrom(*other)
+		}
+	}
+	for _, p := range providers {
+		if p.Name() == source || m.imdbID == "" {
+			continue
+		}
+		if other, err := p.GetByIMDbID(m.imdbID); err == nil && other != nil {
+			m.mergeBlankFrom(*other)
+		}
+	}
+
+	cachePut(*m)
+}
@@ -0,0 +1,98 @@
+package movie
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+)
+
+// Movie holds info about a single movie or TV show, gathered from one or
+// more metadata providers.
+type Movie struct {
+	TMDbID  int
+	Title   string
+	Date    string
+	TMDbURL string
+
+	// Extra fields, filled in by whichever provider has them (see Provider).
+	Plot     string
+	Rated    string
+	Runtime  string
+	Genre    string
+	Director string
+	Actors   string
+	Awards   string
+	Poster   string
+
+	mediaType string
+	imdbID    string
+	source    string // name of the Provider that produced this Movie
+}
+
+var errNoIMDbID = errors.New("No IMDb ID found on this page")
+
+var reIMDbIDOnPage = regexp.MustCompile(`tt[0-9]{7,8}`)
+
+// New creates an empty Movie.
+func New() Movie {
+	return Movie{}
+}
+
+// IMDbID returns the movie's IMDb ID, if known.
+func (m *Movie) IMDbID() string {
+	return m.imdbID
+}
+
+// FetchFromURL fetches a movie's IMDb ID from a Douban movie page.
+func (m *Movie) FetchFromURL(url string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	id := reIMDbIDOnPage.FindString(string(body))
+	if id == "" {
+		return errNoIMDbID
+	}
+	m.imdbID = id
+	return nil
+}
+
+// mergeBlankFrom fills any field left blank by the primary provider with the
+// corresponding value from another provider's result for the same movie.
+func (m *Movie) mergeBlankFrom(other Movie) {
+	if m.imdbID == "" {
+		m.imdbID = other.imdbID
+	}
+	if m.Plot == "" {
+		m.Plot = other.Plot
+	}
+	if m.Rated == "" {
+		m.Rated = other.Rated
+	}
+	if m.Runtime == "" {
+		m.Runtime = other.Runtime
+	}
+	if m.Genre == "" {
+		m.Genre = other.Genre
+	}
+	if m.Director == "" {
+		m.Director = other.Director
+	}
+	if m.Actors == "" {
+		m.Actors = other.Actors
+	}
+	if m.Awards == "" {
+		m.Awards = other.Awards
+	}
+	if m.Poster == "" {
+		m.Poster = other.Poster
+	}
+}
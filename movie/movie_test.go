@@ -0,0 +1,50 @@
+package movie
+
+import "testing"
+
+func TestMergeBlankFrom(t *testing.T) {
+	m := New()
+	m.Plot = "Primary plot"
+	m.imdbID = ""
+
+	other := New()
+	other.imdbID = "tt1234567"
+	other.Plot = "Other plot, should not override"
+	other.Rated = "PG-13"
+	other.Runtime = "120 min"
+	other.Genre = "Drama"
+	other.Director = "Jane Doe"
+	other.Actors = "John Roe"
+	other.Awards = "Nominated"
+	other.Poster = "http://example.com/poster.jpg"
+
+	m.mergeBlankFrom(other)
+
+	if m.imdbID != "tt1234567" {
+		t.Errorf("imdbID = %q, want tt1234567 (was blank, should be filled)", m.imdbID)
+	}
+	if m.Plot != "Primary plot" {
+		t.Errorf("Plot = %q, want Primary plot (already set, should not be overwritten)", m.Plot)
+	}
+	if m.Rated != "PG-13" {
+		t.Errorf("Rated = %q, want PG-13", m.Rated)
+	}
+	if m.Runtime != "120 min" {
+		t.Errorf("Runtime = %q, want 120 min", m.Runtime)
+	}
+	if m.Genre != "Drama" {
+		t.Errorf("Genre = %q, want Drama", m.Genre)
+	}
+	if m.Director != "Jane Doe" {
+		t.Errorf("Director = %q, want Jane Doe", m.Director)
+	}
+	if m.Actors != "John Roe" {
+		t.Errorf("Actors = %q, want John Roe", m.Actors)
+	}
+	if m.Awards != "Nominated" {
+		t.Errorf("Awards = %q, want Nominated", m.Awards)
+	}
+	if m.Poster != "http://example.com/poster.jpg" {
+		t.Errorf("Poster = %q, want http://example.com/poster.jpg", m.Poster)
+	}
+}
@@ -0,0 +1,101 @@
+package movie
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+const omdbURL = "https://www.omdbapi.com/"
+
+var errOMDbNotFound = errors.New("No movies found on OMDb, please check your input")
+
+// InitOMDb enables the OMDb provider as a fallback for whatever TMDb leaves
+// blank or can't find. Call after InitTMDb so TMDb stays the primary
+// provider.
+func InitOMDb() {
+	providers = append(providers, omdbProvider{apiKey: os.Getenv("OMDB_API_KEY")})
+}
+
+// omdbProvider implements Provider on top of the OMDb JSON API.
+type omdbProvider struct {
+	apiKey string
+}
+
+type omdbResponse struct {
+	Title    string
+	Year     string
+	Rated    string
+	Runtime  string
+	Genre    string
+	Director string
+	Actors   string
+	Plot     string
+	Awards   string
+	Poster   string
+	ImdbID   string `json:"imdbID"`
+	Response string
+	Error    string
+}
+
+func (p omdbProvider) query(params url.Values) (*omdbResponse, error) {
+	params.Set("apikey", p.apiKey)
+
+	resp, err := http.Get(omdbURL + "?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r omdbResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+	if r.Response == "False" {
+		return nil, fmt.Errorf("omdb: %s", r.Error)
+	}
+	return &r, nil
+}
+
+func (r omdbResponse) toMovie() Movie {
+	m := New()
+	m.Title = r.Title
+	m.Date = r.Year
+	m.Rated = r.Rated
+	m.Runtime = r.Runtime
+	m.Genre = r.Genre
+	m.Director = r.Director
+	m.Actors = r.Actors
+	m.Plot = r.Plot
+	m.Awards = r.Awards
+	m.Poster = r.Poster
+	m.imdbID = r.ImdbID
+	m.source = "omdb"
+	return m
+}
+
+func (omdbProvider) Name() string { return "omdb" }
+
+func (p omdbProvider) SearchByKeyword(keyword string, limit int) ([]Movie, error) {
+	r, err := p.query(url.Values{"t": {keyword}})
+	if err != nil {
+		return nil, err
+	}
+	return []Movie{r.toMovie()}, nil
+}
+
+func (p omdbProvider) GetByIMDbID(imdbID string) (*Movie, error) {
+	r, err := p.query(url.Values{"i": {imdbID}})
+	if err != nil {
+		return nil, err
+	}
+	m := r.toMovie()
+	return &m, nil
+}
+
+func (p omdbProvider) GetByTMDbID(tmdbID int) (*Movie, error) {
+	return nil, errOMDbNotFound
+}
@@ -0,0 +1,17 @@
+package movie
+
+// Provider is a metadata source that can look up movies by keyword or by a
+// known ID. TMDb is the primary provider; others (e.g. OMDb) fill in
+// whatever fields the primary left blank.
+type Provider interface {
+	// Name identifies the provider, e.g. so enrichment can skip asking the
+	// provider that produced a result for the same result again.
+	Name() string
+	SearchByKeyword(keyword string, limit int) ([]Movie, error)
+	GetByIMDbID(imdbID string) (*Movie, error)
+	GetByTMDbID(tmdbID int) (*Movie, error)
+}
+
+// providers are tried in order: the first provider is authoritative for a
+// search's results, later ones only fill in blanks.
+var providers []Provider
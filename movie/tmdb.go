@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/magunetto/tmdb"
 )
@@ -17,6 +18,12 @@ var (
 	tapi *tmdb.TMDB
 
 	errTMDbSearchNoResult = errors.New("No movies found on TMDb, please check your input")
+
+	// errTMDbNoFindByIMDb is returned by GetByIMDbID: the TMDb client this
+	// bot depends on only exposes lookups by TMDb ID (GetMovie), it has no
+	// find-by-external-id endpoint, so TMDb can never be used to resolve an
+	// IMDb ID into a Movie.
+	errTMDbNoFindByIMDb = errors.New("tmdb: lookup by IMDb ID is not supported by this client")
 )
 
 // InitTMDb init TMDb API
@@ -25,11 +32,17 @@ func InitTMDb() {
 	if os.Getenv("TMDB_API_TOKEN") != "" {
 		tapi.APIKey = os.Getenv("TMDB_API_TOKEN")
 	}
+	providers = append(providers, tmdbProvider{})
 }
 
-// SearchMovies search movies on TMDb
-func SearchMovies(keyword string, limit int) ([]Movie, error) {
+// tmdbProvider implements Provider on top of the TMDb API. It is the
+// primary provider: its search results are authoritative, other providers
+// only fill in fields it left blank.
+type tmdbProvider struct{}
 
+func (tmdbProvider) Name() string { return "tmdb" }
+
+func (tmdbProvider) SearchByKeyword(keyword string, limit int) ([]Movie, error) {
 	result, err := tapi.SearchMulti(keyword)
 	if err != nil {
 		log.Printf("error while querying tmdb: %s", err)
@@ -42,6 +55,46 @@ func SearchMovies(keyword string, limit int) ([]Movie, error) {
 	return newMoviesBySearch(result, limit), nil
 }
 
+func (tmdbProvider) GetByTMDbID(tmdbID int) (*Movie, error) {
+	d, err := tapi.GetMovie(strconv.Itoa(tmdbID))
+	if err != nil {
+		return nil, err
+	}
+	m := New()
+	m.TMDbID = tmdbID
+	m.Title = d.Title
+	m.Date = d.ReleaseDate
+	m.TMDbURL = fmt.Sprintf(tmdbURL, "movie", tmdbID)
+	m.imdbID = d.ImdbID
+	m.source = "tmdb"
+	return &m, nil
+}
+
+// GetByIMDbID always fails: the underlying TMDb client has no
+// find-by-external-id endpoint, only GetMovie(tmdbID).
+func (tmdbProvider) GetByIMDbID(imdbID string) (*Movie, error) {
+	return nil, errTMDbNoFindByIMDb
+}
+
+// SearchMovies searches movies across all enabled providers, in order,
+// falling back to the next one if the primary has nothing for this keyword.
+func SearchMovies(keyword string, limit int) ([]Movie, error) {
+	var lastErr error
+	for _, p := range providers {
+		movies, err := p.SearchByKeyword(keyword, limit)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(movies) == 0 {
+			continue
+		}
+		enrichAll(movies, p.Name())
+		return movies, nil
+	}
+	return nil, lastErr
+}
+
 func newMoviesBySearch(result tmdb.SearchMultiResult, limit int) []Movie {
 
 	movies := []Movie{}
@@ -57,6 +110,7 @@ func newMoviesBySearch(result tmdb.SearchMultiResult, limit int) []Movie {
 		m.Title = r.Title
 		m.Date = r.ReleaseDate
 		m.TMDbURL = fmt.Sprintf(tmdbURL, r.MediaType, r.ID)
+		m.source = "tmdb"
 		if r.MediaType == "tv" {
 			m.Title = r.Name
 			m.Date = r.FirstAirDate
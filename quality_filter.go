@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/magunetto/moviemagnetbot/torrent"
+	"github.com/magunetto/moviemagnetbot/torrent/quality"
+)
+
+// resolutionOrder controls the order buckets are printed in, best first.
+var resolutionOrder = []string{"2160p", "1080p", "720p", "480p", ""}
+
+// groupByQuality applies f and buckets the surviving results by resolution,
+// each bucket sorted by seeders, so users pick a version instead of getting
+// a raw dump.
+func groupByQuality(results []torrent.Result, f quality.Filter) map[string][]torrent.Result {
+	groups := map[string][]torrent.Result{}
+
+	for _, r := range results {
+		attrs := quality.Parse(r.Title)
+		if !f.Match(attrs) {
+			continue
+		}
+		groups[attrs.Resolution] = append(groups[attrs.Resolution], r)
+	}
+
+	for res := range groups {
+		bucket := groups[res]
+		sort.Slice(bucket, func(i, j int) bool { return bucket[i].Seeders > bucket[j].Seeders })
+		groups[res] = bucket
+	}
+
+	return groups
+}
+
+// renderGroupedResults writes one line per quality bucket with its
+// top-seeded result, e.g. as a starting point for a result listing.
+func renderGroupedResults(w io.Writer, results []torrent.Result, f quality.Filter) {
+	groups := groupByQuality(results, f)
+
+	for _, res := range resolutionOrder {
+		bucket := groups[res]
+		if len(bucket) == 0 {
+			continue
+		}
+		label := res
+		if label == "" {
+			label = "unknown"
+		}
+		top := bucket[0]
+		fmt.Fprintf(w, "▸ %s (%d seeders, %s): %s\n", label, top.Seeders, humanizeSize(top.Size), top.Title)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/magunetto/moviemagnetbot/torrent"
+)
+
+// searchTorrents searches every enabled magnet source for clue/keyword
+// (e.g. clue "imdb" with an IMDb ID, or clue "tmdb" with a TMDb ID),
+// persists each result so it can be fetched back by PubStamp, and prints
+// them grouped by quality bucket using telegramID's default filter (see
+// torrent/quality), so the user picks a version instead of getting a raw
+// dump. It returns true if there was exactly one result overall, so
+// callers can decide whether to show a link preview.
+func searchTorrents(w io.Writer, telegramID int, clue, keyword string) bool {
+	results := torrentSources.Search(clue, keyword)
+	if len(results) == 0 {
+		fmt.Fprintln(w, replyNoTorrents)
+		return false
+	}
+
+	saved := make([]torrent.Result, 0, len(results))
+	for _, r := range results {
+		t := &Torrent{
+			PubStamp: time.Now().UnixNano(),
+			InfoHash: r.InfoHash,
+			Title:    r.Title,
+			Magnet:   r.Magnet,
+		}
+		if err := t.save(); err != nil {
+			continue
+		}
+		r.Title = fmt.Sprintf("%s%d %s", cmdPrefixDown, t.PubStamp, r.Title)
+		saved = append(saved, r)
+	}
+
+	u := &User{TelegramID: telegramID}
+	renderGroupedResults(w, saved, u.Filter())
+
+	return len(saved) == 1
+}
@@ -0,0 +1,15 @@
+package stream
+
+import "fmt"
+
+// M3U renders a one-entry .m3u playlist pointing at the stream URL, named
+// after the video file so players show a sensible title.
+func M3U(name, url string) string {
+	return fmt.Sprintf("#EXTM3U\n#EXTINF:-1,%s\n%s\n", name, url)
+}
+
+// Strm renders a .strm snippet, which is just the bare URL: Kodi and mpv
+// both treat a .strm file's contents as the thing to open.
+func Strm(url string) string {
+	return url + "\n"
+}
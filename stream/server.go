@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Server exposes a Manager over HTTP: range-request playback and a small
+// JSON status endpoint, mounted under /stream/.
+type Server struct {
+	mgr *Manager
+}
+
+// NewServer wraps a Manager as an http.Handler.
+func NewServer(mgr *Manager) *Server {
+	return &Server{mgr: mgr}
+}
+
+// Manager returns the underlying stream Manager, e.g. so callers can start
+// new streams directly from a bot handler.
+func (s *Server) Manager() *Manager {
+	return s.mgr
+}
+
+// URL builds the direct playback URL for a stream, to be embedded in the
+// Telegram reply and in generated .m3u/.strm files.
+func (s *Server) URL(baseURL, infoHash string) string {
+	return strings.TrimRight(baseURL, "/") + "/stream/" + infoHash
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/stream/")
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	infoHash := parts[0]
+	stream, ok := s.mgr.Get(infoHash)
+	if !ok {
+		http.Error(w, "unknown or expired stream", http.StatusNotFound)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "status" {
+		s.serveStatus(w, stream)
+		return
+	}
+
+	s.servePlayback(w, r, stream)
+}
+
+func (s *Server) serveStatus(w http.ResponseWriter, stream *Stream) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stream.status())
+}
+
+func (s *Server) servePlayback(w http.ResponseWriter, r *http.Request, stream *Stream) {
+	reader := stream.file.NewReader()
+	defer reader.Close()
+	reader.SetReadahead(stream.file.Length() / 100)
+
+	stream.Acquire()
+	defer stream.Release()
+
+	w.Header().Set("Content-Type", contentType(stream.file.DisplayPath()))
+	// http.ServeContent handles Range requests (and If-Range/seek) for us.
+	http.ServeContent(w, r, stream.file.DisplayPath(), time.Time{}, reader)
+}
+
+// contentType derives the MIME type to serve a video file as from its
+// extension (largestVideoFile can pick any of isVideoFile's extensions, not
+// just .mp4), falling back to video/mp4 for .mp4 or anything unrecognized.
+func contentType(path string) string {
+	if t := mime.TypeByExtension(filepath.Ext(path)); t != "" {
+		return t
+	}
+	return "video/mp4"
+}
@@ -0,0 +1,51 @@
+package stream
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+)
+
+// store persists the magnet URIs of currently active streams to a small JSON
+// file, so a bot restart can re-add them to the torrent client instead of
+// leaving users with dead links.
+type store struct {
+	path string
+}
+
+func newStore(path string) *store {
+	return &store{path: path}
+}
+
+func (s *store) load() []string {
+	if s.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("stream: error while reading state file: %s", err)
+		}
+		return nil
+	}
+	var magnets []string
+	if err := json.Unmarshal(data, &magnets); err != nil {
+		log.Printf("stream: error while parsing state file: %s", err)
+		return nil
+	}
+	return magnets
+}
+
+func (s *store) save(magnets []string) {
+	if s.path == "" {
+		return
+	}
+	data, err := json.Marshal(magnets)
+	if err != nil {
+		log.Printf("stream: error while marshaling state: %s", err)
+		return
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		log.Printf("stream: error while writing state file: %s", err)
+	}
+}
@@ -0,0 +1,267 @@
+// Package stream turns accepted magnets into playable HTTP streams backed by
+// an in-process anacrolix/torrent client, so a user can open a link directly
+// in VLC/mpv/Kodi instead of waiting for a full download.
+package stream
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anacrolix/torrent"
+)
+
+const (
+	// idleTimeout is how long a stream is kept around in the torrent client
+	// after its last reader disconnects before it is dropped.
+	idleTimeout = 15 * time.Minute
+
+	reapInterval = time.Minute
+
+	// metadataTimeout bounds how long StartStream waits for a peer to hand
+	// over a torrent's metadata before giving up on it.
+	metadataTimeout = 30 * time.Second
+)
+
+// Stream wraps a single torrent that is being served for playback.
+type Stream struct {
+	InfoHash string
+	t        *torrent.Torrent
+	file     *torrent.File
+
+	mu       sync.Mutex
+	refs     int
+	lastSeen time.Time
+}
+
+// FileStatus describes download/peer progress for a streamed torrent.
+type FileStatus struct {
+	InfoHash           string `json:"info_hash"`
+	Name               string `json:"name"`
+	Length             int64  `json:"length"`
+	BytesCompleted     int64  `json:"bytes_completed"`
+	Peers              int    `json:"peers"`
+	ActiveReaders      int    `json:"active_readers"`
+	SecondsSinceReader int    `json:"seconds_since_reader"`
+}
+
+// Manager tracks active streams keyed by info hash and evicts idle torrents.
+type Manager struct {
+	cl *torrent.Client
+
+	mu      sync.Mutex
+	streams map[string]*Stream
+
+	store *store
+}
+
+// NewManager creates a Manager backed by a fresh anacrolix/torrent client and
+// restores any streams that were active before the last restart.
+func NewManager(dataDir string, stateFile string) (*Manager, error) {
+	cfg := torrent.NewDefaultClientConfig()
+	cfg.DataDir = dataDir
+	cl, err := torrent.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		cl:      cl,
+		streams: map[string]*Stream{},
+		store:   newStore(stateFile),
+	}
+
+	// Restore in the background: a stream whose peers never answer
+	// shouldn't be able to hold up the rest of RunBot from starting.
+	go func() {
+		for _, magnetURI := range m.store.load() {
+			if _, err := m.StartStream(magnetURI); err != nil {
+				log.Printf("stream: error while restoring %s: %s", magnetURI, err)
+			}
+		}
+	}()
+
+	go m.reapLoop()
+
+	return m, nil
+}
+
+var errNoVideoFile = errors.New("no video file found in torrent")
+var errMetadataTimeout = errors.New("timed out waiting for torrent metadata")
+
+// StartStream adds the magnet to the torrent client (if not already added),
+// waits for metadata, and picks the largest video file to serve.
+func (m *Manager) StartStream(magnetURI string) (*Stream, error) {
+	t, err := m.cl.AddMagnet(magnetURI)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-t.GotInfo():
+	case <-time.After(metadataTimeout):
+		t.Drop()
+		return nil, errMetadataTimeout
+	}
+
+	infoHash := t.InfoHash().HexString()
+
+	m.mu.Lock()
+	if s, ok := m.streams[infoHash]; ok {
+		m.mu.Unlock()
+		s.touch()
+		return s, nil
+	}
+	m.mu.Unlock()
+
+	f := largestVideoFile(t)
+	if f == nil {
+		t.Drop()
+		return nil, errNoVideoFile
+	}
+	f.Download()
+
+	s := &Stream{InfoHash: infoHash, t: t, file: f, lastSeen: time.Now()}
+
+	m.mu.Lock()
+	m.streams[infoHash] = s
+	m.mu.Unlock()
+
+	m.store.save(m.activeMagnets())
+
+	return s, nil
+}
+
+// Client returns the Manager's underlying anacrolix/torrent client, so
+// other packages (e.g. magnet) can resolve metainfo against the same
+// swarm connections instead of spinning up a second client.
+func (m *Manager) Client() *torrent.Client {
+	return m.cl
+}
+
+// Get returns the stream for an already-started info hash, if any.
+func (m *Manager) Get(infoHash string) (*Stream, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.streams[infoHash]
+	return s, ok
+}
+
+// Status reports progress for every tracked stream.
+func (m *Manager) Status() []FileStatus {
+	m.mu.Lock()
+	streams := make([]*Stream, 0, len(m.streams))
+	for _, s := range m.streams {
+		streams = append(streams, s)
+	}
+	m.mu.Unlock()
+
+	out := make([]FileStatus, 0, len(streams))
+	for _, s := range streams {
+		out = append(out, s.status())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].InfoHash < out[j].InfoHash })
+	return out
+}
+
+func (m *Manager) activeMagnets() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	magnets := make([]string, 0, len(m.streams))
+	for _, s := range m.streams {
+		magnets = append(magnets, s.t.Metainfo().Magnet(nil, s.t.Info()).String())
+	}
+	return magnets
+}
+
+func (m *Manager) reapLoop() {
+	for range time.Tick(reapInterval) {
+		m.reapIdle()
+	}
+}
+
+func (m *Manager) reapIdle() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for infoHash, s := range m.streams {
+		if s.idleFor() < idleTimeout {
+			continue
+		}
+		log.Printf("stream: dropping idle torrent %s", infoHash)
+		s.t.Drop()
+		delete(m.streams, infoHash)
+	}
+
+	m.store.save(m.activeMagnetsLocked())
+}
+
+func (m *Manager) activeMagnetsLocked() []string {
+	magnets := make([]string, 0, len(m.streams))
+	for _, s := range m.streams {
+		magnets = append(magnets, s.t.Metainfo().Magnet(nil, s.t.Info()).String())
+	}
+	return magnets
+}
+
+func largestVideoFile(t *torrent.Torrent) *torrent.File {
+	var best *torrent.File
+	for _, f := range t.Files() {
+		if !isVideoFile(f.Path()) {
+			continue
+		}
+		if best == nil || f.Length() > best.Length() {
+			best = f
+		}
+	}
+	return best
+}
+
+// Acquire marks a reader as attached to the stream, preventing idle eviction.
+func (s *Stream) Acquire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refs++
+}
+
+// Release detaches a reader from the stream.
+func (s *Stream) Release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refs--
+	s.lastSeen = time.Now()
+}
+
+func (s *Stream) touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSeen = time.Now()
+}
+
+func (s *Stream) idleFor() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs > 0 {
+		return 0
+	}
+	return time.Since(s.lastSeen)
+}
+
+func (s *Stream) status() FileStatus {
+	s.mu.Lock()
+	refs := s.refs
+	idle := time.Since(s.lastSeen)
+	s.mu.Unlock()
+
+	return FileStatus{
+		InfoHash:           s.InfoHash,
+		Name:               s.file.DisplayPath(),
+		Length:             s.file.Length(),
+		BytesCompleted:     s.file.BytesCompleted(),
+		Peers:              s.t.Stats().ActivePeers,
+		ActiveReaders:      refs,
+		SecondsSinceReader: int(idle.Seconds()),
+	}
+}
@@ -0,0 +1,17 @@
+package stream
+
+import "strings"
+
+var videoExtensions = []string{
+	".mp4", ".mkv", ".avi", ".mov", ".webm", ".m4v", ".wmv", ".flv", ".ts",
+}
+
+func isVideoFile(path string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range videoExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package torrent
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// Aggregator fans a search out across all enabled sources concurrently,
+// merges and de-duplicates the results, and re-ranks them by seeders.
+type Aggregator struct {
+	sources []Source
+	timeout time.Duration
+}
+
+// NewAggregator creates an Aggregator over the given sources. timeout bounds
+// how long a single slow source can hold up the reply; sources that don't
+// answer in time are simply left out of the results.
+func NewAggregator(sources []Source, timeout time.Duration) *Aggregator {
+	return &Aggregator{sources: sources, timeout: timeout}
+}
+
+// Search queries every enabled source concurrently and returns the merged,
+// de-duplicated, seeder-ranked results.
+func (a *Aggregator) Search(clue, keyword string) []Result {
+	type sourceResult struct {
+		results []Result
+		err     error
+	}
+
+	replies := make(chan sourceResult, len(a.sources))
+	for _, s := range a.sources {
+		s := s
+		go func() {
+			results, err := s.Search(clue, keyword)
+			replies <- sourceResult{results: results, err: err}
+		}()
+	}
+
+	timeout := time.After(a.timeout)
+	merged := []Result{}
+collect:
+	for i := 0; i < len(a.sources); i++ {
+		select {
+		case r := <-replies:
+			if r.err != nil {
+				log.Printf("torrent: source search error: %s", r.err)
+				continue
+			}
+			merged = append(merged, r.results...)
+		case <-timeout:
+			log.Printf("torrent: %d source(s) timed out after %s", len(a.sources)-i, a.timeout)
+			break collect
+		}
+	}
+
+	merged = dedupeByInfoHash(merged)
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Seeders > merged[j].Seeders })
+	return merged
+}
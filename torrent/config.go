@@ -0,0 +1,39 @@
+package torrent
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const searchTimeout = 8 * time.Second
+
+// NewAggregatorFromEnv builds an Aggregator from the sources named in the
+// ENABLED_SOURCES env var, e.g. "rarbg,yts,tpb". Defaults to "rarbg" to
+// match the bot's original behavior if the var is unset.
+func NewAggregatorFromEnv() *Aggregator {
+	enabled := os.Getenv("ENABLED_SOURCES")
+	if enabled == "" {
+		enabled = "rarbg"
+	}
+
+	sources := []Source{}
+	for _, name := range strings.Split(enabled, ",") {
+		switch strings.TrimSpace(name) {
+		case "rarbg":
+			s, err := NewRARBGSource()
+			if err != nil {
+				log.Printf("torrent: error while enabling rarbg source: %s", err)
+				continue
+			}
+			sources = append(sources, s)
+		case "yts":
+			sources = append(sources, NewYTSSource())
+		case "tpb":
+			sources = append(sources, NewTPBSource())
+		}
+	}
+
+	return NewAggregator(sources, searchTimeout)
+}
@@ -0,0 +1,54 @@
+package torrent
+
+import (
+	"fmt"
+	"net/url"
+
+	magnetpkg "github.com/magunetto/moviemagnetbot/magnet"
+)
+
+// infoHashFromMagnet extracts the canonical 40-char hex info hash from a
+// magnet URI, used to de-duplicate results across sources. Returns "" if
+// the magnet can't be parsed (e.g. a base32 hash).
+func infoHashFromMagnet(magnet string) string {
+	m, err := magnetpkg.Parse(magnet)
+	if err != nil {
+		return ""
+	}
+	return m.InfoHash
+}
+
+// magnetFromInfoHash builds a bare magnet link for sources (YTS, TPB) that
+// only give us an info hash and a display name rather than a full magnet.
+func magnetFromInfoHash(infoHash, displayName string) string {
+	return fmt.Sprintf("magnet:?xt=urn:btih:%s&dn=%s", infoHash, url.QueryEscape(displayName))
+}
+
+// dedupeByInfoHash collapses results that share an info hash, keeping the
+// one with the most seeders.
+func dedupeByInfoHash(results []Result) []Result {
+	best := map[string]Result{}
+	order := []string{}
+
+	for _, r := range results {
+		key := r.InfoHash
+		if key == "" {
+			key = r.Magnet
+		}
+		existing, ok := best[key]
+		if !ok {
+			order = append(order, key)
+			best[key] = r
+			continue
+		}
+		if r.Seeders > existing.Seeders {
+			best[key] = r
+		}
+	}
+
+	out := make([]Result, 0, len(order))
+	for _, key := range order {
+		out = append(out, best[key])
+	}
+	return out
+}
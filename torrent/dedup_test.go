@@ -0,0 +1,41 @@
+package torrent
+
+import "testing"
+
+func TestDedupeByInfoHash(t *testing.T) {
+	hash := "2b66980693c7aa0580d4b7280a6f6f06047dcb5a"
+
+	results := []Result{
+		{Title: "Movie.RARBG", InfoHash: hash, Seeders: 10, Source: "rarbg"},
+		{Title: "Movie.YTS", InfoHash: hash, Seeders: 50, Source: "yts"},
+		{Title: "Other.Movie", InfoHash: "ffffffffffffffffffffffffffffffffffffffff", Seeders: 5, Source: "tpb"},
+	}
+
+	out := dedupeByInfoHash(results)
+
+	if len(out) != 2 {
+		t.Fatalf("len(out) = %d, want 2", len(out))
+	}
+	if out[0].InfoHash != hash || out[0].Seeders != 50 {
+		t.Errorf("out[0] = %+v, want the higher-seeder duplicate", out[0])
+	}
+	if out[1].Title != "Other.Movie" {
+		t.Errorf("out[1].Title = %q, want Other.Movie", out[1].Title)
+	}
+}
+
+func TestDedupeByInfoHashFallsBackToMagnet(t *testing.T) {
+	results := []Result{
+		{Title: "A", Magnet: "magnet:?xt=urn:btih:same", Seeders: 1},
+		{Title: "B", Magnet: "magnet:?xt=urn:btih:same", Seeders: 2},
+	}
+
+	out := dedupeByInfoHash(results)
+
+	if len(out) != 1 {
+		t.Fatalf("len(out) = %d, want 1", len(out))
+	}
+	if out[0].Title != "B" {
+		t.Errorf("out[0].Title = %q, want B (higher seeders)", out[0].Title)
+	}
+}
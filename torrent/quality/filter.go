@@ -0,0 +1,53 @@
+package quality
+
+import "strings"
+
+// Filter describes the quality criteria a user wants applied to search
+// results, e.g. parsed from a `/dl<stamp> 1080p bluray no-cam` suffix.
+type Filter struct {
+	Resolution string // "" matches any
+	Source     string // "" matches any
+	AllowCam   bool   // false (the default) hides cam/telesync releases
+}
+
+// DefaultFilter hides cam/telesync releases and otherwise accepts anything.
+var DefaultFilter = Filter{AllowCam: false}
+
+// ParseFilter reads filter tokens such as "1080p bluray no-cam" out of a
+// command suffix and applies them on top of base, so tokens the suffix
+// doesn't mention (e.g. a saved resolution preference when the suffix only
+// says "cam") are left as base had them. Unrecognized tokens are ignored.
+func ParseFilter(base Filter, suffix string) Filter {
+	f := base
+
+	for _, tok := range strings.Fields(strings.ToLower(suffix)) {
+		switch tok {
+		case "no-cam", "nocam":
+			f.AllowCam = false
+		case "cam", "allow-cam":
+			f.AllowCam = true
+		default:
+			if r, ok := resolutionTokens[tok]; ok {
+				f.Resolution = r
+			} else if s, ok := sourceTokens[tok]; ok {
+				f.Source = s
+			}
+		}
+	}
+
+	return f
+}
+
+// Match reports whether a release's attributes satisfy the filter.
+func (f Filter) Match(a Attributes) bool {
+	if a.CamOrTS && !f.AllowCam {
+		return false
+	}
+	if f.Resolution != "" && a.Resolution != f.Resolution {
+		return false
+	}
+	if f.Source != "" && a.Source != f.Source {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+// Package quality parses a torrent release name into structured attributes
+// (resolution, source, codec, audio, HDR, cam/telesync) so results can be
+// filtered and grouped instead of dumped as a flat list.
+package quality
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Attributes describes what a release name told us about the release.
+type Attributes struct {
+	Resolution string // 480p, 720p, 1080p, 2160p, "" if unknown
+	Source     string // BluRay, WEB-DL, HDRip, ...
+	Codec      string // x264, x265, AV1, ...
+	Audio      string // AAC, DTS, Atmos, ...
+	HDR        bool
+	CamOrTS    bool // cam/telesync-type release, see camTokens
+}
+
+var reNonWord = regexp.MustCompile(`\W+`)
+
+var resolutionTokens = map[string]string{
+	"480p": "480p", "720p": "720p", "1080p": "1080p", "2160p": "2160p", "4k": "2160p",
+}
+
+var sourceTokens = map[string]string{
+	"bluray": "BluRay", "bdrip": "BluRay", "brrip": "BluRay",
+	"webdl": "WEB-DL", "web-dl": "WEB-DL", "webrip": "WEB-DL",
+	"hdrip": "HDRip", "dvdrip": "DVDRip", "hdtv": "HDTV",
+}
+
+var codecTokens = map[string]string{
+	"x264": "x264", "h264": "x264", "x265": "x265", "h265": "x265",
+	"hevc": "x265", "av1": "AV1",
+}
+
+var audioTokens = map[string]string{
+	"aac": "AAC", "dts": "DTS", "atmos": "Atmos", "ac3": "AC3", "ddp5": "DDP5.1", "eac3": "EAC3",
+}
+
+var hdrTokens = map[string]bool{
+	"hdr": true, "hdr10": true, "hdr10plus": true, "dolbyvision": true, "dv": true,
+}
+
+// camTokens are release-name tokens that flag a pirated cam/telesync rip
+// rather than a proper source (cf. https://en.wikipedia.org/wiki/Pirated_film_release_types).
+var camTokens = map[string]bool{
+	"cam": true, "camrip": true, "hdcam": true,
+	"ts": true, "tsrip": true, "hdts": true, "telesync": true,
+	"pdvd": true, "predvdrip": true,
+	"tc": true, "hdtc": true, "telecine": true,
+	"wp": true, "workprint": true,
+}
+
+// Parse tokenizes a release name on non-word characters and matches each
+// token, case-insensitively, against the known attribute vocabularies.
+func Parse(releaseName string) Attributes {
+	var a Attributes
+
+	for _, tok := range tokenize(releaseName) {
+		if r, ok := resolutionTokens[tok]; ok {
+			a.Resolution = r
+		}
+		if s, ok := sourceTokens[tok]; ok {
+			a.Source = s
+		}
+		if c, ok := codecTokens[tok]; ok {
+			a.Codec = c
+		}
+		if au, ok := audioTokens[tok]; ok {
+			a.Audio = au
+		}
+		if hdrTokens[tok] {
+			a.HDR = true
+		}
+		if camTokens[tok] {
+			a.CamOrTS = true
+		}
+	}
+
+	return a
+}
+
+func tokenize(s string) []string {
+	tokens := reNonWord.Split(s, -1)
+	out := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t == "" {
+			continue
+		}
+		out = append(out, strings.ToLower(t))
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package quality
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	a := Parse("Some.Movie.2024.1080p.BluRay.x265.DTS-HDR10-GROUP")
+
+	if a.Resolution != "1080p" {
+		t.Errorf("Resolution = %q, want 1080p", a.Resolution)
+	}
+	if a.Source != "BluRay" {
+		t.Errorf("Source = %q, want BluRay", a.Source)
+	}
+	if a.Codec != "x265" {
+		t.Errorf("Codec = %q, want x265", a.Codec)
+	}
+	if a.Audio != "DTS" {
+		t.Errorf("Audio = %q, want DTS", a.Audio)
+	}
+	if !a.HDR {
+		t.Error("HDR = false, want true")
+	}
+	if a.CamOrTS {
+		t.Error("CamOrTS = true, want false")
+	}
+}
+
+func TestParseCamOrTS(t *testing.T) {
+	for _, name := range []string{
+		"Some.Movie.2024.HDCAM.x264-GROUP",
+		"Some.Movie.2024.TELESYNC.x264-GROUP",
+		"Some.Movie.2024.HDTS.x264-GROUP",
+	} {
+		if a := Parse(name); !a.CamOrTS {
+			t.Errorf("Parse(%q).CamOrTS = false, want true", name)
+		}
+	}
+}
+
+func TestParseUnknownTokensIgnored(t *testing.T) {
+	a := Parse("Some.Movie.2024.GROUP")
+	if a.Resolution != "" || a.Source != "" || a.Codec != "" || a.Audio != "" || a.HDR || a.CamOrTS {
+		t.Errorf("Parse with no known tokens should return zero Attributes, got %+v", a)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Filter
+		a    Attributes
+		want bool
+	}{
+		{"default filter hides cam", DefaultFilter, Attributes{CamOrTS: true}, false},
+		{"default filter allows non-cam", DefaultFilter, Attributes{Resolution: "1080p"}, true},
+		{"allow-cam accepts cam", Filter{AllowCam: true}, Attributes{CamOrTS: true}, true},
+		{"resolution mismatch rejected", Filter{Resolution: "1080p"}, Attributes{Resolution: "720p"}, false},
+		{"resolution match accepted", Filter{Resolution: "1080p"}, Attributes{Resolution: "1080p"}, true},
+		{"source mismatch rejected", Filter{Source: "BluRay"}, Attributes{Source: "WEB-DL"}, false},
+		{"source match accepted", Filter{Source: "BluRay"}, Attributes{Source: "BluRay"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.f.Match(tt.a); got != tt.want {
+			t.Errorf("%s: Match() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	f := ParseFilter(DefaultFilter, "1080p bluray cam")
+	if f.Resolution != "1080p" {
+		t.Errorf("Resolution = %q, want 1080p", f.Resolution)
+	}
+	if f.Source != "BluRay" {
+		t.Errorf("Source = %q, want BluRay", f.Source)
+	}
+	if !f.AllowCam {
+		t.Error("AllowCam = false, want true")
+	}
+}
@@ -0,0 +1,68 @@
+package torrent
+
+import (
+	"time"
+
+	api "github.com/umayr/go-torrentapi"
+)
+
+const (
+	rarbgRanked = true            // Should results be ranked
+	rarbgSort   = "seeders"       // Sort order (seeders, leechers, last)
+	rarbgFormat = "json_extended" // Format (json, json_extended)
+	rarbgLimit  = 25              // Limit of results (25, 50, 100)
+
+	rarbgPubDateLayout = "2006-01-02 15:04:05 -0700"
+)
+
+// rarbgSource implements Source on top of the RARBG TorrentAPI.
+type rarbgSource struct {
+	api *api.API
+}
+
+// NewRARBGSource creates a Source backed by the RARBG TorrentAPI. The
+// library fetches and renews its own token internally (there's no way to
+// hand it a pre-existing one via Config), so construction can fail if that
+// initial token request fails.
+func NewRARBGSource() (Source, error) {
+	a, err := api.New()
+	if err != nil {
+		return nil, err
+	}
+	return withRateLimit(rarbgSource{api: a}, 1, 2), nil
+}
+
+func (rarbgSource) Name() string { return "rarbg" }
+
+func (s rarbgSource) Search(clue, keyword string) ([]Result, error) {
+	switch clue {
+	case "tvdb":
+		s.api.SearchTVDB(keyword)
+	case "imdb":
+		s.api.SearchImDB(keyword)
+	default:
+		s.api.SearchString(keyword)
+	}
+
+	s.api.Ranked(rarbgRanked).Sort(rarbgSort).Format(rarbgFormat).Limit(rarbgLimit)
+	found, err := s.api.Search()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(found))
+	for _, t := range found {
+		pubDate, _ := time.Parse(rarbgPubDateLayout, t.PubDate)
+		results = append(results, Result{
+			Title:    t.Title,
+			Magnet:   t.Download,
+			InfoHash: infoHashFromMagnet(t.Download),
+			Size:     uint64(t.Size),
+			Seeders:  t.Seeders,
+			Leechers: t.Leechers,
+			Source:   "rarbg",
+			PubDate:  pubDate,
+		})
+	}
+	return results, nil
+}
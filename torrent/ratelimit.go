@@ -0,0 +1,28 @@
+package torrent
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimited wraps a Source with a token bucket so a misbehaving or
+// aggressively-polled source can't get the bot banned, without affecting
+// the other enabled sources.
+type rateLimited struct {
+	Source
+	limiter *rate.Limiter
+}
+
+// withRateLimit allows up to burst requests immediately, then one every
+// 1/rps seconds.
+func withRateLimit(s Source, rps float64, burst int) Source {
+	return rateLimited{Source: s, limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+}
+
+func (s rateLimited) Search(clue, keyword string) ([]Result, error) {
+	if err := s.limiter.Wait(context.Background()); err != nil {
+		return nil, err
+	}
+	return s.Source.Search(clue, keyword)
+}
@@ -0,0 +1,26 @@
+// Package torrent aggregates magnet search results across multiple
+// trackers/APIs behind a single Source interface.
+package torrent
+
+import "time"
+
+// Result is a single magnet search result, normalized across sources.
+type Result struct {
+	Title    string
+	Magnet   string
+	InfoHash string
+	Size     uint64
+	Seeders  int
+	Leechers int
+	Source   string
+	PubDate  time.Time
+}
+
+// Source is a searchable magnet backend, e.g. an API or a scraper.
+type Source interface {
+	// Name identifies the source, e.g. for ENABLED_SOURCES and result tagging.
+	Name() string
+	// Search looks up results for a clue ("imdb", "tmdb", "search", ...) and
+	// its keyword (an IMDb ID, a TMDb ID, free text, ...).
+	Search(clue, keyword string) ([]Result, error)
+}
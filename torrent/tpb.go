@@ -0,0 +1,69 @@
+package torrent
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const tpbSearchURL = "https://apibay.org/q.php?q=%s&cat=200"
+
+// tpbSource implements Source by scraping The Pirate Bay's unofficial JSON
+// mirror (apibay), since TPB has no official API.
+type tpbSource struct{}
+
+// NewTPBSource creates a Source backed by The Pirate Bay.
+func NewTPBSource() Source {
+	return withRateLimit(tpbSource{}, 1, 3)
+}
+
+func (tpbSource) Name() string { return "tpb" }
+
+type tpbEntry struct {
+	Name     string `json:"name"`
+	InfoHash string `json:"info_hash"`
+	Size     string `json:"size"`
+	Seeders  string `json:"seeders"`
+	Leechers string `json:"leechers"`
+}
+
+func (tpbSource) Search(clue, keyword string) ([]Result, error) {
+	if clue == "tvdb" {
+		return nil, nil
+	}
+
+	resp, err := http.Get(fmt.Sprintf(tpbSearchURL, keyword))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var entries []tpbEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(entries))
+	for _, e := range entries {
+		if e.InfoHash == "" || e.InfoHash == "0000000000000000000000000000000000000000" {
+			continue
+		}
+		seeders, _ := strconv.Atoi(e.Seeders)
+		leechers, _ := strconv.Atoi(e.Leechers)
+		size, _ := strconv.ParseUint(e.Size, 10, 64)
+		infoHash := strings.ToLower(e.InfoHash)
+
+		results = append(results, Result{
+			Title:    e.Name,
+			Magnet:   magnetFromInfoHash(infoHash, e.Name),
+			InfoHash: infoHash,
+			Size:     size,
+			Seeders:  seeders,
+			Leechers: leechers,
+			Source:   "tpb",
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,74 @@
+package torrent
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const ytsSearchURL = "https://yts.mx/api/v2/list_movies.json?query_term=%s&limit=25"
+
+// ytsSource implements Source on top of the YTS movies API. YTS is
+// movies-only and only useful for "imdb"/"search" clues.
+type ytsSource struct{}
+
+// NewYTSSource creates a Source backed by the YTS API.
+func NewYTSSource() Source {
+	return withRateLimit(ytsSource{}, 2, 5)
+}
+
+func (ytsSource) Name() string { return "yts" }
+
+type ytsResponse struct {
+	Data struct {
+		Movies []struct {
+			Title string `json:"title"`
+			Torrents []struct {
+				Hash     string `json:"hash"`
+				Quality  string `json:"quality"`
+				Seeds    int    `json:"seeds"`
+				Peers    int    `json:"peers"`
+				SizeByte uint64 `json:"size_bytes"`
+				DateAt   string `json:"date_uploaded"`
+			} `json:"torrents"`
+		} `json:"movies"`
+	} `json:"data"`
+}
+
+func (ytsSource) Search(clue, keyword string) ([]Result, error) {
+	if clue == "tvdb" {
+		return nil, nil
+	}
+
+	resp, err := http.Get(fmt.Sprintf(ytsSearchURL, keyword))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r ytsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	for _, movie := range r.Data.Movies {
+		for _, t := range movie.Torrents {
+			pubDate, _ := time.Parse("2006-01-02 15:04:05", t.DateAt)
+			infoHash := strings.ToLower(t.Hash)
+			results = append(results, Result{
+				Title:    fmt.Sprintf("%s [%s]", movie.Title, t.Quality),
+				Magnet:   magnetFromInfoHash(infoHash, movie.Title),
+				InfoHash: infoHash,
+				Size:     t.SizeByte,
+				Seeders:  t.Seeds,
+				Leechers: t.Peers,
+				Source:   "yts",
+				PubDate:  pubDate,
+			})
+		}
+	}
+	return results, nil
+}
@@ -3,29 +3,15 @@ package main
 import (
 	"fmt"
 
-	api "github.com/umayr/go-torrentapi"
+	"github.com/magunetto/moviemagnetbot/torrent"
 )
 
-const (
-	ranked = true            // Should results be ranked
-	sort   = "seeders"       // Sort order (seeders, leechers, last)
-	format = "json_extended" // Format (json, json_extended)
-	limit  = 25              // Limit of results (25, 50, 100)
-)
-
-func search(api *api.API, clue string, keyword string) (results api.TorrentResults, err error) {
-	switch clue {
-	case "tvdb":
-		api.SearchTVDB(keyword)
-	case "imdb":
-		api.SearchImDB(keyword)
-	case "search":
-		api.SearchString(keyword)
-	}
+// torrentSources aggregates magnet results across every source enabled via
+// ENABLED_SOURCES (see torrent.NewAggregatorFromEnv).
+var torrentSources *torrent.Aggregator
 
-	api.Ranked(ranked).Sort(sort).Format(format).Limit(limit)
-	results, err = api.Search()
-	return
+func initTorrentSources() {
+	torrentSources = torrent.NewAggregatorFromEnv()
 }
 
 func humanizeSize(s uint64) string {
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/magunetto/moviemagnetbot/downloader"
+	"github.com/magunetto/moviemagnetbot/torrent/quality"
+)
+
+const userSettingsFile = "data/user_settings.json"
+
+// userSettings is the part of a User's record that isn't tied to a single
+// torrent: their default quality filter and, if they've run /setclient,
+// their torrent client credentials. Kept in one file keyed by TelegramID so
+// both ride alongside the rest of the User record instead of each growing
+// its own store.
+type userSettings struct {
+	Filter       quality.Filter    `json:"filter"`
+	ClientConfig downloader.Config `json:"client_config,omitempty"`
+}
+
+var userSettingsStore = struct {
+	sync.RWMutex
+	byTelegramID map[int]userSettings
+}{byTelegramID: loadUserSettings()}
+
+func loadUserSettings() map[int]userSettings {
+	settings := map[int]userSettings{}
+	data, err := os.ReadFile(userSettingsFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("error while reading user settings: %s", err)
+		}
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		log.Printf("error while parsing user settings: %s", err)
+	}
+	return settings
+}
+
+func saveUserSettingsLocked() {
+	data, err := json.Marshal(userSettingsStore.byTelegramID)
+	if err != nil {
+		log.Printf("error while marshaling user settings: %s", err)
+		return
+	}
+	if err := os.WriteFile(userSettingsFile, data, 0644); err != nil {
+		log.Printf("error while writing user settings: %s", err)
+	}
+}
+
+// Filter returns u's default quality.Filter, or quality.DefaultFilter if
+// they haven't set one.
+func (u *User) Filter() quality.Filter {
+	userSettingsStore.RLock()
+	defer userSettingsStore.RUnlock()
+	if s, ok := userSettingsStore.byTelegramID[u.TelegramID]; ok {
+		return s.Filter
+	}
+	return quality.DefaultFilter
+}
+
+// SetFilter saves f as u's default quality filter.
+func (u *User) SetFilter(f quality.Filter) {
+	userSettingsStore.Lock()
+	defer userSettingsStore.Unlock()
+	s := userSettingsStore.byTelegramID[u.TelegramID]
+	s.Filter = f
+	userSettingsStore.byTelegramID[u.TelegramID] = s
+	saveUserSettingsLocked()
+}
+
+// ClientConfig returns u's configured torrent client, if they've run
+// /setclient.
+func (u *User) ClientConfig() (downloader.Config, bool) {
+	userSettingsStore.RLock()
+	defer userSettingsStore.RUnlock()
+	s, ok := userSettingsStore.byTelegramID[u.TelegramID]
+	return s.ClientConfig, ok && s.ClientConfig.Kind != ""
+}
+
+// SetClientConfig saves cfg as u's torrent client.
+func (u *User) SetClientConfig(cfg downloader.Config) {
+	userSettingsStore.Lock()
+	defer userSettingsStore.Unlock()
+	s := userSettingsStore.byTelegramID[u.TelegramID]
+	s.ClientConfig = cfg
+	userSettingsStore.byTelegramID[u.TelegramID] = s
+	saveUserSettingsLocked()
+}